@@ -0,0 +1,250 @@
+package adapters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"chainlink/core/eth"
+	"chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+// encodeABICall ABI-encodes result into calldata for method, per the ABI in
+// abiJSON. result may be a JSON object keyed by argument name, or a JSON
+// array of positional arguments; each value is coerced to the Go type the
+// corresponding argument's Solidity type expects (hex strings to
+// common.Address/[]byte/common.Hash, JSON numbers to *big.Int or a sized
+// int/uint, JSON arrays to slices).
+func encodeABICall(abiJSON json.RawMessage, method string, result gjson.Result) ([]byte, error) {
+	parsedABI, err := abi.JSON(bytes.NewReader(abiJSON))
+	if err != nil {
+		return nil, errors.Wrap(err, "while parsing EthTx ABI")
+	}
+	m, exists := parsedABI.Methods[method]
+	if !exists {
+		return nil, fmt.Errorf("no method %q in ABI", method)
+	}
+
+	args := make([]interface{}, len(m.Inputs))
+	for i, input := range m.Inputs {
+		var raw gjson.Result
+		if result.IsArray() {
+			raw = result.Get(strconv.Itoa(i))
+		} else {
+			raw = result.Get(input.Name)
+		}
+		arg, err := coerceArg(input.Type, raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while coercing argument %q", input.Name)
+		}
+		args[i] = arg
+	}
+
+	return parsedABI.Pack(method, args...)
+}
+
+// coerceArg converts a gjson.Result into the Go representation t expects, per
+// go-ethereum's accounts/abi argument packing rules.
+func coerceArg(t abi.Type, v gjson.Result) (interface{}, error) {
+	switch t.T {
+	case abi.AddressTy:
+		return common.HexToAddress(v.String()), nil
+	case abi.HashTy:
+		return common.HexToHash(v.String()), nil
+	case abi.BytesTy:
+		return common.FromHex(v.String()), nil
+	case abi.FixedBytesTy:
+		decoded := common.FromHex(v.String())
+		out := reflect.New(t.GetType()).Elem()
+		reflect.Copy(out, reflect.ValueOf(decoded))
+		return out.Interface(), nil
+	case abi.BoolTy:
+		return v.Bool(), nil
+	case abi.StringTy:
+		return v.String(), nil
+	case abi.IntTy, abi.UintTy:
+		n, err := parseBigInt(v)
+		if err != nil {
+			return nil, err
+		}
+		return coerceToIntType(t, n), nil
+	case abi.SliceTy:
+		elems := v.Array()
+		slice := reflect.MakeSlice(t.GetType(), len(elems), len(elems))
+		for i, elem := range elems {
+			coerced, err := coerceArg(*t.Elem, elem)
+			if err != nil {
+				return nil, err
+			}
+			slice.Index(i).Set(reflect.ValueOf(coerced))
+		}
+		return slice.Interface(), nil
+	case abi.ArrayTy:
+		elems := v.Array()
+		if len(elems) != t.Size {
+			return nil, fmt.Errorf("expected %d elements for %s, got %d", t.Size, t.String(), len(elems))
+		}
+		array := reflect.New(t.GetType()).Elem()
+		for i, elem := range elems {
+			coerced, err := coerceArg(*t.Elem, elem)
+			if err != nil {
+				return nil, err
+			}
+			array.Index(i).Set(reflect.ValueOf(coerced))
+		}
+		return array.Interface(), nil
+	default:
+		return nil, fmt.Errorf("unsupported ABI type %s for job spec argument coercion", t.String())
+	}
+}
+
+// parseBigInt parses an integer argument out of v, which may be an unquoted
+// JSON number (v.Raw, e.g. 1000) or a quoted JSON string (v.Str, e.g.
+// "10000000000000000000") -- the usual workaround for amounts too large to
+// round-trip through a JSON/JS float64, such as an 18-decimal token amount.
+// v.Int() must not be used here: for a gjson.String value it silently
+// parses into an int64, wrapping any amount above ~9.2e18.
+func parseBigInt(v gjson.Result) (*big.Int, error) {
+	digits := v.Raw
+	if v.Type == gjson.String {
+		digits = v.Str
+	}
+	n, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("could not parse %q as an integer", digits)
+	}
+	return n, nil
+}
+
+// coerceToIntType narrows n down to whatever native Go integer type t's
+// Solidity size maps to (e.g. uint8, int64), or leaves it as *big.Int for
+// sizes too big to fit a native type.
+func coerceToIntType(t abi.Type, n *big.Int) interface{} {
+	switch t.GetType().Kind() {
+	case reflect.Uint8:
+		return uint8(n.Uint64())
+	case reflect.Uint16:
+		return uint16(n.Uint64())
+	case reflect.Uint32:
+		return uint32(n.Uint64())
+	case reflect.Uint64:
+		return n.Uint64()
+	case reflect.Int8:
+		return int8(n.Int64())
+	case reflect.Int16:
+		return int16(n.Int64())
+	case reflect.Int32:
+		return int32(n.Int64())
+	case reflect.Int64:
+		return n.Int64()
+	default:
+		return n
+	}
+}
+
+// decodeReceiptLogs ABI-decodes each log's data against the event it matches
+// in abiJSON (by topic0), for attaching to the run output as diagnostic data.
+// Logs that don't match any event in the ABI, or that fail to decode, are
+// skipped rather than failing the whole adapter.
+func decodeReceiptLogs(abiJSON json.RawMessage, logs []eth.Log) ([]models.JSON, error) {
+	parsedABI, err := abi.JSON(bytes.NewReader(abiJSON))
+	if err != nil {
+		return nil, errors.Wrap(err, "while parsing EthTx ABI")
+	}
+
+	var decoded []models.JSON
+	for _, log := range logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+		event, found := eventByTopic(parsedABI, log.Topics[0])
+		if !found {
+			continue
+		}
+
+		// Arguments.Unpack only returns values for the event's non-indexed
+		// inputs -- indexed inputs are carried in log.Topics[1:] instead, so
+		// the two must be zipped against event.Inputs separately rather than
+		// by a shared index.
+		values, err := event.Inputs.NonIndexed().Unpack(log.Data)
+		if err != nil {
+			continue
+		}
+
+		entry := models.JSON{}
+		entry, err = entry.Add("event", event.Name)
+		if err != nil {
+			return nil, err
+		}
+		valueIdx, topicIdx := 0, 1
+		for _, input := range event.Inputs {
+			if input.Indexed {
+				if topicIdx >= len(log.Topics) {
+					continue
+				}
+				topic := log.Topics[topicIdx]
+				topicIdx++
+				arg, ok := decodeIndexedArg(input.Type, topic)
+				if !ok {
+					continue
+				}
+				entry, err = entry.Add(input.Name, renderArg(arg))
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if valueIdx >= len(values) {
+				continue
+			}
+			entry, err = entry.Add(input.Name, renderArg(values[valueIdx]))
+			if err != nil {
+				return nil, err
+			}
+			valueIdx++
+		}
+		decoded = append(decoded, entry)
+	}
+	return decoded, nil
+}
+
+// decodeIndexedArg decodes an indexed event argument of type t out of topic.
+// Only non-dynamic types (address, bool, int/uint, bytesN) are recoverable
+// this way -- for dynamic types (string, bytes, slices/arrays), solc stores
+// keccak256(value) in the topic instead of the value itself, so there's
+// nothing to decode back.
+func decodeIndexedArg(t abi.Type, topic common.Hash) (interface{}, bool) {
+	switch t.T {
+	case abi.AddressTy:
+		return common.BytesToAddress(topic.Bytes()), true
+	case abi.BoolTy:
+		return topic.Big().Sign() != 0, true
+	case abi.IntTy, abi.UintTy:
+		return topic.Big(), true
+	case abi.FixedBytesTy:
+		out := reflect.New(t.GetType()).Elem()
+		reflect.Copy(out, reflect.ValueOf(topic.Bytes()))
+		return out.Interface(), true
+	default:
+		return nil, false
+	}
+}
+
+// eventByTopic finds the ABI event whose signature hash matches topic0, the
+// first entry in every non-anonymous event log's Topics.
+func eventByTopic(parsedABI abi.ABI, topic0 common.Hash) (abi.Event, bool) {
+	for _, event := range parsedABI.Events {
+		if event.Id() == topic0 {
+			return event, true
+		}
+	}
+	return abi.Event{}, false
+}
@@ -0,0 +1,140 @@
+package adapters
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"chainlink/core/eth"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func uint256Type(t *testing.T) abi.Type {
+	typ, err := abi.NewType("uint256", nil)
+	require.NoError(t, err)
+	return typ
+}
+
+func TestCoerceArg_UintFromUnquotedNumber(t *testing.T) {
+	v := gjson.Parse(`1000`)
+	arg, err := coerceArg(uint256Type(t), v)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), arg)
+}
+
+func TestCoerceArg_UintFromQuotedString(t *testing.T) {
+	// 18-decimal token amount, too large to round-trip through a JSON number
+	// without losing precision -- the standard reason to quote it.
+	amount := "10000000000000000000"
+	v := gjson.Parse(`"` + amount + `"`)
+
+	arg, err := coerceArg(uint256Type(t), v)
+	require.NoError(t, err)
+
+	expected, ok := new(big.Int).SetString(amount, 10)
+	require.True(t, ok)
+	assert.Equal(t, expected, arg)
+
+	// Sanity check this amount really would overflow an int64, which is
+	// exactly the bug this test guards against.
+	assert.True(t, expected.Cmp(big.NewInt(math.MaxInt64)) > 0)
+}
+
+func TestCoerceArg_UintFromGarbageString(t *testing.T) {
+	v := gjson.Parse(`"not a number"`)
+	_, err := coerceArg(uint256Type(t), v)
+	assert.Error(t, err)
+}
+
+func TestCoerceArg_FixedSizeArray(t *testing.T) {
+	typ, err := abi.NewType("uint256[3]", nil)
+	require.NoError(t, err)
+
+	v := gjson.Parse(`[1, 2, 3]`)
+	arg, err := coerceArg(typ, v)
+	require.NoError(t, err)
+	assert.Equal(t, [3]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}, arg)
+}
+
+func TestCoerceArg_FixedSizeArrayWrongLength(t *testing.T) {
+	typ, err := abi.NewType("uint256[3]", nil)
+	require.NoError(t, err)
+
+	v := gjson.Parse(`[1, 2]`)
+	_, err = coerceArg(typ, v)
+	assert.Error(t, err)
+}
+
+const transferEventABI = `[{
+	"name": "Transfer",
+	"type": "event",
+	"anonymous": false,
+	"inputs": [
+		{"name": "from", "type": "address", "indexed": true},
+		{"name": "to", "type": "address", "indexed": true},
+		{"name": "value", "type": "uint256", "indexed": false}
+	]
+}]`
+
+func TestDecodeReceiptLogs_IndexedAndNonIndexedArgs(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(transferEventABI))
+	require.NoError(t, err)
+	event := parsedABI.Events["Transfer"]
+
+	from := common.HexToAddress("0x00000000000000000000000000000000000a11")
+	to := common.HexToAddress("0x00000000000000000000000000000000000a22")
+	value := big.NewInt(1000)
+
+	data, err := event.Inputs.NonIndexed().Pack(value)
+	require.NoError(t, err)
+
+	log := eth.Log{
+		Topics: []common.Hash{event.Id(), from.Hash(), to.Hash()},
+		Data:   data,
+	}
+
+	decoded, err := decodeReceiptLogs(json.RawMessage(transferEventABI), []eth.Log{log})
+	require.NoError(t, err)
+	require.Len(t, decoded, 1)
+
+	entry := decoded[0]
+	assert.Equal(t, "Transfer", entry.Get("event").String())
+	assert.Equal(t, from.Hex(), entry.Get("from").String())
+	assert.Equal(t, to.Hex(), entry.Get("to").String())
+	assert.Equal(t, "1000", entry.Get("value").String())
+}
+
+const noIndexedArgsEventABI = `[{
+	"name": "Notify",
+	"type": "event",
+	"anonymous": false,
+	"inputs": [
+		{"name": "value", "type": "uint256", "indexed": false}
+	]
+}]`
+
+func TestDecodeReceiptLogs_NoIndexedArgs(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(noIndexedArgsEventABI))
+	require.NoError(t, err)
+	event := parsedABI.Events["Notify"]
+
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(42))
+	require.NoError(t, err)
+
+	log := eth.Log{
+		Topics: []common.Hash{event.Id()},
+		Data:   data,
+	}
+
+	decoded, err := decodeReceiptLogs(json.RawMessage(noIndexedArgsEventABI), []eth.Log{log})
+	require.NoError(t, err)
+	require.Len(t, decoded, 1)
+	assert.Equal(t, "42", decoded[0].Get("value").String())
+}
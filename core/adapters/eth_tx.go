@@ -33,6 +33,19 @@ type EthTx struct {
 	DataFormat       string               `json:"format"`
 	GasPrice         *utils.Big           `json:"gasPrice" gorm:"type:numeric"`
 	GasLimit         uint64               `json:"gasLimit"`
+	// ContractABI is an optional contract metadata blob ({ "abi": ...,
+	// "userdoc": ..., "devdoc": ... }, as emitted by solc) used to resolve a
+	// human-readable NatSpec description of the call FunctionSelector makes.
+	// If empty, or if no method in it matches FunctionSelector, the adapter
+	// behaves exactly as it did before this field existed.
+	ContractABI json.RawMessage `json:"contractABI,omitempty"`
+	// ABI and Method, when both present, replace the FunctionSelector +
+	// DataPrefix + DataFormat encoding entirely: input.Result() is ABI-encoded
+	// against Method's inputs, supporting arbitrary Solidity argument tuples
+	// rather than a single word or bytes payload. The same ABI is used to
+	// decode the eventual receipt's logs once the transaction confirms.
+	ABI    json.RawMessage `json:"abi,omitempty"`
+	Method string          `json:"method,omitempty"`
 }
 
 // Perform creates the run result for the transaction if the existing run result
@@ -44,21 +57,46 @@ func (etx *EthTx) Perform(input models.RunInput, store *strpkg.Store) models.Run
 	}
 
 	if input.Status().PendingConfirmations() {
-		return ensureTxRunResult(input, store)
+		return etx.ensureTxRunResult(input, store)
 	}
 
-	value, err := getTxData(etx, input)
-	if err != nil {
-		err = errors.Wrap(err, "while constructing EthTx data")
-		return models.NewRunOutputError(err)
+	var data []byte
+	var notice, devdoc string
+	if len(etx.ABI) > 0 && etx.Method != "" {
+		packed, err := encodeABICall(etx.ABI, etx.Method, input.Result())
+		if err != nil {
+			err = errors.Wrap(err, "while constructing EthTx data")
+			return models.NewRunOutputError(err)
+		}
+		data = packed
+	} else {
+		value, err := getTxData(etx, input)
+		if err != nil {
+			err = errors.Wrap(err, "while constructing EthTx data")
+			return models.NewRunOutputError(err)
+		}
+		data = utils.ConcatBytes(etx.FunctionSelector.Bytes(), etx.DataPrefix, value)
+
+		var natspecErr error
+		notice, devdoc, natspecErr = resolveNatSpec(etx.ContractABI, etx.FunctionSelector, utils.ConcatBytes(etx.DataPrefix, value))
+		if natspecErr != nil {
+			logger.Warnw("unable to resolve NatSpec for EthTx", "error", natspecErr, "address", etx.Address.Hex())
+		}
+	}
+
+	if notice != "" {
+		logger.Infow(notice, "address", etx.Address.Hex(), "functionSelector", etx.FunctionSelector.String())
+	}
+	if devdoc != "" {
+		logger.Debugw("EthTx devdoc", "devdoc", devdoc, "address", etx.Address.Hex())
 	}
 
-	data := utils.ConcatBytes(etx.FunctionSelector.Bytes(), etx.DataPrefix, value)
-	return createTxRunResult(etx.Address, etx.GasPrice, etx.GasLimit, data, input, store)
+	return createTxRunResult(etx.Address, etx.GasPrice, etx.GasLimit, data, notice, input, store)
 }
 
 // getTxData returns the data to save against the callback encoded according to
-// the dataFormat parameter in the job spec
+// the dataFormat parameter in the job spec. It's only used when the job spec
+// doesn't configure ABI+Method encoding.
 func getTxData(e *EthTx, input models.RunInput) ([]byte, error) {
 	result := input.Result()
 	if e.DataFormat == "" {
@@ -81,6 +119,7 @@ func createTxRunResult(
 	gasPrice *utils.Big,
 	gasLimit uint64,
 	data []byte,
+	notice string,
 	input models.RunInput,
 	store *strpkg.Store,
 ) models.RunOutput {
@@ -103,6 +142,13 @@ func createTxRunResult(
 		return models.NewRunOutputError(err)
 	}
 
+	if notice != "" {
+		output, err = output.Add("notice", notice)
+		if err != nil {
+			return models.NewRunOutputError(err)
+		}
+	}
+
 	// txAttempt := tx.Attempts[0]
 	// receipt, state, err := store.TxManager.CheckAttempt(txAttempt, tx.SentAt)
 	// if err != nil {
@@ -125,7 +171,7 @@ func createTxRunResult(
 	return models.NewRunOutputPendingConfirmationsWithData(output)
 }
 
-func ensureTxRunResult(input models.RunInput, str *strpkg.Store) models.RunOutput {
+func (etx *EthTx) ensureTxRunResult(input models.RunInput, str *strpkg.Store) models.RunOutput {
 	val, err := input.ResultString()
 	if err != nil {
 		return models.NewRunOutputError(err)
@@ -160,11 +206,11 @@ func ensureTxRunResult(input models.RunInput, str *strpkg.Store) models.RunOutpu
 		if err != nil {
 			return models.NewRunOutputError(err)
 		}
-		return addReceiptToResult(receipt, input, output)
+		return etx.addReceiptToResult(receipt, input, output)
 	}
 }
 
-func addReceiptToResult(
+func (etx *EthTx) addReceiptToResult(
 	receipt *eth.TxReceipt,
 	input models.RunInput,
 	data models.JSON,
@@ -193,6 +239,19 @@ func addReceiptToResult(
 	if err != nil {
 		return models.NewRunOutputError(err)
 	}
+
+	if len(etx.ABI) > 0 {
+		decodedLogs, err := decodeReceiptLogs(etx.ABI, receipt.Logs)
+		if err != nil {
+			logger.Warnw("unable to decode EthTx receipt logs", "error", err, "address", etx.Address.Hex())
+		} else if len(decodedLogs) > 0 {
+			data, err = data.Add("decodedLogs", decodedLogs)
+			if err != nil {
+				return models.NewRunOutputError(err)
+			}
+		}
+	}
+
 	return models.NewRunOutputComplete(data)
 }
 
@@ -0,0 +1,147 @@
+package adapters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"chainlink/core/eth"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// contractDoc is the NatSpec userdoc/devdoc shape solc emits, e.g.
+//
+//   { "methods": { "methodSig(uint256,address)": { "notice": "Send `amount` tokens to `recipient`" } } }
+type contractDoc struct {
+	Methods map[string]methodDoc `json:"methods"`
+}
+
+// methodDoc is a single method's NatSpec annotations. solc's devdoc schema
+// keys the @dev description as "details" (alongside "params" and "returns"
+// maps we don't currently surface), not "dev".
+type methodDoc struct {
+	Notice  string            `json:"notice"`
+	Details string            `json:"details"`
+	Params  map[string]string `json:"params"`
+	Returns map[string]string `json:"returns"`
+}
+
+// contractMetadata is the shape of the EthTx.ContractABI job spec field: a
+// contract's ABI alongside the NatSpec userdoc/devdoc solc compiled it with.
+type contractMetadata struct {
+	ABI     abi.ABI     `json:"abi"`
+	UserDoc contractDoc `json:"userdoc"`
+	DevDoc  contractDoc `json:"devdoc"`
+}
+
+// placeholderRe matches the backtick-delimited argument names NatSpec
+// templates use, e.g. "Send `amount` tokens to `recipient`".
+var placeholderRe = regexp.MustCompile("`([a-zA-Z0-9_]+)`")
+
+// resolveNatSpec looks up, within contractABI, the method whose 4-byte
+// selector matches selector, decodes callData against that method's inputs,
+// and renders its @notice and @dev templates with each `argName` placeholder
+// replaced by the human-readable form of the corresponding decoded argument.
+//
+// It returns empty strings, not an error, if contractABI is empty or no
+// method matches the selector, so callers can silently fall back to today's
+// behavior.
+func resolveNatSpec(contractABI json.RawMessage, selector eth.FunctionSelector, callData []byte) (notice, devdoc string, err error) {
+	if len(contractABI) == 0 {
+		return "", "", nil
+	}
+	var meta contractMetadata
+	if err := json.Unmarshal(contractABI, &meta); err != nil {
+		return "", "", errors.Wrap(err, "while parsing ContractABI")
+	}
+
+	method, found := methodBySelector(meta.ABI, selector)
+	if !found {
+		return "", "", nil
+	}
+	sig := methodSignature(method)
+
+	args := decodeArgs(method, callData)
+	notice = renderTemplate(meta.UserDoc.Methods[sig].Notice, args)
+	devdoc = renderTemplate(meta.DevDoc.Methods[sig].Details, args)
+	return notice, devdoc, nil
+}
+
+// methodBySelector finds the ABI method whose 4-byte selector matches
+// selector.
+func methodBySelector(contractABI abi.ABI, selector eth.FunctionSelector) (method abi.Method, found bool) {
+	for _, method := range contractABI.Methods {
+		if bytes.Equal(method.Id(), selector.Bytes()) {
+			return method, true
+		}
+	}
+	return abi.Method{}, false
+}
+
+// methodSignature builds the canonical "name(type,type)" solc signature for
+// method. abi.ABI.Methods is keyed by the bare method name, but solc's
+// userdoc/devdoc are keyed by this canonical signature, so the two can't be
+// used interchangeably.
+func methodSignature(method abi.Method) string {
+	types := make([]string, len(method.Inputs))
+	for i, input := range method.Inputs {
+		types[i] = input.Type.String()
+	}
+	return fmt.Sprintf("%s(%s)", method.Name, strings.Join(types, ","))
+}
+
+// decodeArgs ABI-decodes callData against method's inputs, returning a map
+// of argument name to its human-friendly rendering. Decoding failures are
+// swallowed; a NatSpec template with an unresolvable placeholder is left
+// untouched rather than failing the whole adapter.
+func decodeArgs(method abi.Method, callData []byte) map[string]string {
+	args := map[string]string{}
+	values, err := method.Inputs.Unpack(callData)
+	if err != nil {
+		return args
+	}
+	for i, input := range method.Inputs {
+		if i < len(values) {
+			args[input.Name] = renderArg(values[i])
+		}
+	}
+	return args
+}
+
+func renderTemplate(template string, args map[string]string) string {
+	if template == "" {
+		return ""
+	}
+	return placeholderRe.ReplaceAllStringFunc(template, func(match string) string {
+		name := strings.Trim(match, "`")
+		if value, ok := args[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// renderArg renders a decoded ABI value the way an operator would want to
+// read it: addresses as 0x..., big integers as decimal.
+func renderArg(value interface{}) string {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case *big.Int:
+		return v.String()
+	case []byte:
+		return hexutilEncode(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func hexutilEncode(b []byte) string {
+	return "0x" + common.Bytes2Hex(b)
+}
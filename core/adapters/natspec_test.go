@@ -0,0 +1,95 @@
+package adapters
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"chainlink/core/eth"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const transferContractABI = `{
+	"abi": [{
+		"name": "transfer",
+		"type": "function",
+		"inputs": [
+			{"name": "recipient", "type": "address"},
+			{"name": "amount", "type": "uint256"}
+		],
+		"outputs": [{"name": "", "type": "bool"}]
+	}],
+	"userdoc": {
+		"methods": {
+			"transfer(address,uint256)": {
+				"notice": "Send ` + "`amount`" + ` tokens to ` + "`recipient`" + `"
+			}
+		}
+	},
+	"devdoc": {
+		"methods": {
+			"transfer(address,uint256)": {
+				"details": "Moves ` + "`amount`" + ` tokens from the caller to ` + "`recipient`" + `",
+				"params": {
+					"recipient": "the account receiving the tokens",
+					"amount": "the amount, in wei, to transfer"
+				},
+				"returns": {
+					"_0": "true if the transfer succeeded"
+				}
+			}
+		}
+	}
+}`
+
+func mustPackTransfer(t *testing.T, recipient common.Address, amount *big.Int) (eth.FunctionSelector, []byte) {
+	var parsed struct {
+		ABI json.RawMessage `json:"abi"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(transferContractABI), &parsed))
+
+	contractABI, err := abi.JSON(bytes.NewReader(parsed.ABI))
+	require.NoError(t, err)
+
+	method := contractABI.Methods["transfer"]
+	packed, err := method.Inputs.Pack(recipient, amount)
+	require.NoError(t, err)
+
+	var selector eth.FunctionSelector
+	copy(selector[:], method.Id())
+	return selector, packed
+}
+
+func TestResolveNatSpec_MultiArgMethod(t *testing.T) {
+	recipient := common.HexToAddress("0x000000000000000000000000000000deadbeef")
+	amount := big.NewInt(1000)
+	selector, callData := mustPackTransfer(t, recipient, amount)
+
+	notice, devdoc, err := resolveNatSpec(json.RawMessage(transferContractABI), selector, callData)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Send 1000 tokens to "+recipient.Hex(), notice)
+	assert.Equal(t, "Moves 1000 tokens from the caller to "+recipient.Hex(), devdoc)
+}
+
+func TestResolveNatSpec_NoMatchingMethod(t *testing.T) {
+	var unmatchedSelector eth.FunctionSelector
+	copy(unmatchedSelector[:], []byte{0xde, 0xad, 0xbe, 0xef})
+
+	notice, devdoc, err := resolveNatSpec(json.RawMessage(transferContractABI), unmatchedSelector, nil)
+	require.NoError(t, err)
+	assert.Empty(t, notice)
+	assert.Empty(t, devdoc)
+}
+
+func TestResolveNatSpec_NoContractABI(t *testing.T) {
+	notice, devdoc, err := resolveNatSpec(nil, eth.FunctionSelector{}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, notice)
+	assert.Empty(t, devdoc)
+}
@@ -0,0 +1,335 @@
+// Package solidity wraps the solc command line compiler, in the same spirit
+// as go-ethereum's common/compiler package, so that chainlink can consume
+// typed compiler output instead of string-matching on raw JSON.
+package solidity
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Contract contains information about a compiled contract, along with its
+// code.
+type Contract struct {
+	Code []byte       `json:"code"`
+	Info ContractInfo `json:"info"`
+}
+
+// ContractInfo contains information about a compiled contract, including access
+// to the ABI definition, source mapping, user and developer docs, and metadata.
+//
+// Depending on the solc version used to compile the contract, some fields
+// may be left at their zero value.
+type ContractInfo struct {
+	Source          string          `json:"source"`
+	Language        string          `json:"language"`
+	LanguageVersion string          `json:"languageVersion"`
+	CompilerVersion string          `json:"compilerVersion"`
+	CompilerOptions string          `json:"compilerOptions"`
+	SrcMapRuntime   string          `json:"srcMapRuntime"`
+	ABI             json.RawMessage `json:"abiDefinition"`
+	UserDoc         json.RawMessage `json:"userDoc"`
+	DeveloperDoc    json.RawMessage `json:"developerDoc"`
+	Metadata        string          `json:"metadata"`
+}
+
+// solidityVersion represents the output of `solc --version`.
+type solidityVersion struct {
+	Path, Version, FullVersion string
+	Major, Minor, Patch        int
+}
+
+var versionRegexp = regexp.MustCompile(`([0-9]+\.[0-9]+\.[0-9]+)`)
+
+// SolidityVersion runs solc --version and parses the output into a
+// solidityVersion. solcPath may be empty, in which case "solc" is looked up
+// on the PATH.
+func SolidityVersion(solcPath string) (*solidityVersion, error) {
+	if solcPath == "" {
+		solcPath = "solc"
+	}
+	var out strOutput
+	cmd := exec.Command(solcPath, "--version")
+	if err := out.run(cmd); err != nil {
+		return nil, errors.Wrap(err, "failed to run solc --version")
+	}
+	matches := versionRegexp.FindStringSubmatch(out.stdout)
+	if len(matches) < 2 {
+		return nil, fmt.Errorf("could not find version in solc output: %q", out.stdout)
+	}
+	s := &solidityVersion{
+		Path:        solcPath,
+		FullVersion: out.stdout,
+		Version:     matches[1],
+	}
+	parts := strings.SplitN(matches[1], ".", 3)
+	if len(parts) == 3 {
+		s.Major, _ = strconv.Atoi(parts[0])
+		s.Minor, _ = strconv.Atoi(parts[1])
+		s.Patch, _ = strconv.Atoi(parts[2])
+	}
+	return s, nil
+}
+
+// combinedJSONArgs are the flags passed to solc to request everything
+// CompileSolidity needs to populate a Contract.
+const combinedJSONArgs = "bin,abi,userdoc,devdoc,metadata,srcmap-runtime"
+
+// CompileSolidity compiles the given source files with the solc binary at
+// solcPath (or "solc" on the PATH, if solcPath is empty), and returns a map
+// of contract name to its compiled Contract.
+//
+// It normalizes away the differences between solc's 0.5.x/0.6.x/0.7.x
+// --combined-json output formats, so callers never need to know which
+// compiler version produced the output. It does not read recorded artifacts
+// such as LinkToken's abi/bytecode-at-top-level schema; ParseArtifact does.
+func CompileSolidity(solcPath string, sources ...string) (map[string]*Contract, error) {
+	if len(sources) == 0 {
+		return nil, errors.New("solidity: no source files")
+	}
+	if solcPath == "" {
+		solcPath = "solc"
+	}
+	args := []string{"--combined-json", combinedJSONArgs, "--optimize"}
+	args = append(args, sources...)
+
+	var out strOutput
+	cmd := exec.Command(solcPath, args...)
+	if err := out.run(cmd); err != nil {
+		return nil, errors.Wrapf(err, "failed to compile %v", sources)
+	}
+	contracts, err := ParseCombinedJSON([]byte(out.stdout))
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := SolidityVersion(solcPath)
+	if err != nil {
+		return nil, err
+	}
+	source, err := concatSources(sources)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range contracts {
+		c.Info.Source = source
+		c.Info.LanguageVersion = version.Version
+		c.Info.CompilerVersion = version.Version
+		c.Info.CompilerOptions = combinedJSONArgs
+	}
+	return contracts, nil
+}
+
+// concatSources reads and concatenates the given source files, in the order
+// given, for recording on each resulting Contract's Info.Source.
+func concatSources(sources []string) (string, error) {
+	var concatenated strings.Builder
+	for _, path := range sources {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to read source file %s", path)
+		}
+		concatenated.Write(content)
+		concatenated.WriteByte('\n')
+	}
+	return concatenated.String(), nil
+}
+
+// combinedOutput mirrors the shape solc emits for --combined-json.
+type combinedOutput struct {
+	Contracts map[string]struct {
+		Bin           string `json:"bin"`
+		Abi           json.RawMessage
+		Userdoc       json.RawMessage
+		Devdoc        json.RawMessage
+		Metadata      string
+		SrcMapRuntime string `json:"srcmap-runtime"`
+	}
+	Version string
+}
+
+// linkTokenOutput is the schema LinkToken.json's compiler artifact uses:
+// the ABI and bytecode live at the top level, rather than nested under a
+// per-contract "contracts" map.
+type linkTokenOutput struct {
+	ABI      json.RawMessage `json:"abi"`
+	Bytecode string          `json:"bytecode"`
+}
+
+// ParseCombinedJSON parses the output of `solc --combined-json` into typed
+// Contracts. solc keys Contracts as "path/to/File.sol:Name" across the
+// 0.5.x/0.6.x/0.7.x versions we support, so a single name-extraction rule
+// covers all of them; Info.Source, Info.LanguageVersion, Info.CompilerVersion
+// and Info.CompilerOptions are left for CompileSolidity to fill in, since
+// they depend on the solc invocation rather than anything in this JSON.
+func ParseCombinedJSON(combinedJSON []byte) (map[string]*Contract, error) {
+	var output combinedOutput
+	if err := json.Unmarshal(combinedJSON, &output); err != nil {
+		return nil, errors.Wrap(err, "could not parse combined-json output")
+	}
+
+	contracts := make(map[string]*Contract, len(output.Contracts))
+	for name, info := range output.Contracts {
+		// solc keys contracts as "path/to/File.sol:Name" regardless of
+		// version; the name we want is after the last colon.
+		if idx := strings.LastIndex(name, ":"); idx >= 0 {
+			name = name[idx+1:]
+		}
+		contracts[name] = &Contract{
+			Code: commonHexToBytes(info.Bin),
+			Info: ContractInfo{
+				Language:      "Solidity",
+				SrcMapRuntime: info.SrcMapRuntime,
+				ABI:           info.Abi,
+				UserDoc:       info.Userdoc,
+				DeveloperDoc:  info.Devdoc,
+				Metadata:      info.Metadata,
+			},
+		}
+	}
+	return contracts, nil
+}
+
+// Artifact is a previously-recorded compiler artifact for a single contract,
+// of the kind record_versions.sh checks into generation/, together with the
+// solidity source it was compiled from.
+type Artifact struct {
+	Contract *Contract
+	// Sources maps each source file path (relative to the truffle contracts/
+	// directory) to the source code it contained when the artifact was
+	// recorded.
+	Sources map[string]string
+}
+
+// sourceArtifact is the shape written by sol-compiler / "yarn compile" for
+// most contracts: the compiler output is nested under "compilerOutput", and
+// the sources it was compiled from are recorded alongside it so later runs
+// can detect source drift.
+type sourceArtifact struct {
+	CompilerOutput struct {
+		Abi json.RawMessage `json:"abi"`
+		Evm struct {
+			Bytecode struct {
+				Object string `json:"object"`
+			} `json:"bytecode"`
+		} `json:"evm"`
+		Userdoc  json.RawMessage `json:"userdoc"`
+		Devdoc   json.RawMessage `json:"devdoc"`
+		Metadata string          `json:"metadata"`
+	} `json:"compilerOutput"`
+	Sources map[string]string `json:"sourceCodes"`
+}
+
+// ParseArtifact parses a recorded compiler artifact of the kind read by
+// go_generate_test.go, normalizing the two schemas in use: the
+// "compilerOutput.{abi,evm.bytecode.object}" shape most contracts use, and
+// LinkToken's own "abi"/"bytecode" top-level shape.
+func ParseArtifact(artifactJSON []byte) (*Artifact, error) {
+	var linkOutput linkTokenOutput
+	if err := json.Unmarshal(artifactJSON, &linkOutput); err == nil && len(linkOutput.ABI) > 0 {
+		return &Artifact{
+			Contract: &Contract{
+				Code: commonHexToBytes(linkOutput.Bytecode),
+				Info: ContractInfo{
+					Language: "Solidity",
+					ABI:      linkOutput.ABI,
+				},
+			},
+		}, nil
+	}
+
+	var artifact sourceArtifact
+	if err := json.Unmarshal(artifactJSON, &artifact); err != nil {
+		return nil, errors.Wrap(err, "could not parse compiler artifact")
+	}
+	return &Artifact{
+		Contract: &Contract{
+			Code: commonHexToBytes(artifact.CompilerOutput.Evm.Bytecode.Object),
+			Info: ContractInfo{
+				Source:       concatSourceCodes(artifact.Sources),
+				Language:     "Solidity",
+				ABI:          artifact.CompilerOutput.Abi,
+				UserDoc:      artifact.CompilerOutput.Userdoc,
+				DeveloperDoc: artifact.CompilerOutput.Devdoc,
+				Metadata:     artifact.CompilerOutput.Metadata,
+			},
+		},
+		Sources: artifact.Sources,
+	}, nil
+}
+
+// concatSourceCodes concatenates the recorded source of each file in
+// sources, in a stable (sorted-by-path) order, for Info.Source. A single
+// artifact's "sourceCodes" often spans the contract file and the libraries
+// it imports, so there's no one file that alone is "the" source. Each file
+// is followed by a newline, as in what CompileSolidity records for
+// Info.Source, but CompileSolidity keeps the order the files were given in.
+func concatSourceCodes(sources map[string]string) string {
+	paths := make([]string, 0, len(sources))
+	for path := range sources {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var concatenated strings.Builder
+	for _, path := range paths {
+		concatenated.WriteString(sources[path])
+		concatenated.WriteByte('\n')
+	}
+	return concatenated.String()
+}
+
+// metadataLengthBytes is the number of trailing bytes solc appends to
+// runtime bytecode to encode the swarm/ipfs metadata hash (106 trailing hex
+// characters, i.e. 53 bytes). See
+// https://solidity.readthedocs.io/en/latest/metadata.html#encoding-of-the-metadata-hash-in-the-bytecode
+const metadataLengthBytes = 53
+
+// WithoutMetadata returns c's Code with the trailing solc metadata hash
+// stripped, for callers (like the vrf wrapper-hash check) that want a
+// metadata-independent bytecode comparison.
+func (c *Contract) WithoutMetadata() []byte {
+	if len(c.Code) < metadataLengthBytes {
+		return c.Code
+	}
+	return c.Code[:len(c.Code)-metadataLengthBytes]
+}
+
+func commonHexToBytes(s string) []byte {
+	s = strings.TrimPrefix(s, "0x")
+	if len(s)%2 != 0 {
+		s = "0" + s
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// strOutput runs cmd and captures stdout/stderr, returning a wrapped error
+// that includes stderr if the command fails.
+type strOutput struct {
+	stdout, stderr string
+}
+
+func (o *strOutput) run(cmd *exec.Cmd) error {
+	var stdout, stderr strings.Builder
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	o.stdout, o.stderr = stdout.String(), stderr.String()
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, o.stderr)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package solidity
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixtureSource = "testdata/Greeter.sol"
+
+func skipIfNoSolc(t *testing.T) string {
+	solcPath, err := exec.LookPath("solc")
+	if err != nil {
+		t.Skip("solc not installed, skipping")
+	}
+	return solcPath
+}
+
+func TestCompileSolidity(t *testing.T) {
+	solcPath := skipIfNoSolc(t)
+
+	contracts, err := CompileSolidity(solcPath, fixtureSource)
+	require.NoError(t, err)
+	require.Contains(t, contracts, "Greeter")
+
+	greeter := contracts["Greeter"]
+	assert.NotEmpty(t, greeter.Code)
+	assert.NotEmpty(t, greeter.Info.ABI)
+	assert.Contains(t, string(greeter.Info.UserDoc), "Says hello")
+	assert.NotEmpty(t, greeter.WithoutMetadata())
+	assert.True(t, len(greeter.WithoutMetadata()) < len(greeter.Code))
+}
+
+func TestSolidityVersion(t *testing.T) {
+	solcPath := skipIfNoSolc(t)
+
+	version, err := SolidityVersion(solcPath)
+	require.NoError(t, err)
+	assert.NotEmpty(t, version.Version)
+	assert.Regexp(t, `^[0-9]+\.[0-9]+\.[0-9]+$`, version.Version)
+}
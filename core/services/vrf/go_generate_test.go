@@ -5,7 +5,6 @@ package vrf_test
 import (
 	"bufio"
 	"crypto/sha256"
-	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -17,10 +16,10 @@ import (
 	"strings"
 	"testing"
 
+	"chainlink/core/services/solidity"
 	"chainlink/core/utils"
 
 	"github.com/pkg/errors"
-	"github.com/tidwall/gjson"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -58,14 +57,10 @@ func TestCheckContractHashesFromLastGoGenerate(t *testing.T) {
 // contractVersion.hash, and that the solidity source code recorded in the
 // compiler artifact matches the current solidity contracts.
 //
-// Most of the compiler artifacts should contain output from sol-compiler, or
-// "yarn compile". The relevant parts of its schema are
-//
-//    { "sourceCodes": { "<filePath>": "<code>", ... } }
-//
-// where <filePath> is the path to the contract, below the truffle contracts/
-// directory, and <code> is the source code of the contract at the time the JSON
-// file was generated.
+// The compiler artifacts are parsed with solidity.ParseArtifact, which
+// normalizes both the sol-compiler/"yarn compile" schema
+// (`compilerOutput.abi` / `compilerOutput.evm.bytecode.object`) most
+// contracts use, and LinkToken's own `abi`/`bytecode` top-level schema.
 func compareCurrentCompilerAritfactAgainstRecordsAndSoliditySources(
 	t *testing.T, versionInfo contractVersion,
 ) {
@@ -73,23 +68,21 @@ func compareCurrentCompilerAritfactAgainstRecordsAndSoliditySources(
 	// check the compiler outputs (abi and bytecode object) haven't changed
 	compilerJSON, err := ioutil.ReadFile(apath)
 	require.NoError(t, err, "failed to read JSON compiler artifact %s", apath)
-	abiPath := "compilerOutput.abi"
-	binPath := "compilerOutput.evm.bytecode.object"
 	isLINKCompilerOutput :=
 		path.Base(versionInfo.compilerArtifactPath) == "LinkToken.json"
-	if isLINKCompilerOutput {
-		abiPath = "abi"
-		binPath = "bytecode"
-	}
+
+	artifact, err := solidity.ParseArtifact(compilerJSON)
+	require.NoError(t, err, "could not parse compiler artifact %s", apath)
+
 	// Normalize the whitespace in the ABI JSON
-	abiBytes := stripWhitespace(gjson.GetBytes(compilerJSON, abiPath).String(), "")
-	binBytes := gjson.GetBytes(compilerJSON, binPath).String()
+	abiBytes := stripWhitespace(string(artifact.Contract.Info.ABI), "")
+	binBytes := artifact.Contract.Code
 	if !isLINKCompilerOutput {
 		// Remove the varying contract metadata, as in ./generation/generate.sh
-		binBytes = binBytes[:len(binBytes)-106]
+		binBytes = artifact.Contract.WithoutMetadata()
 	}
 	hasher := sha256.New()
-	hashMsg := string(abiBytes+binBytes) + "\n" // newline from <<< in record_versions.sh
+	hashMsg := abiBytes + fmt.Sprintf("%x", binBytes) + "\n" // newline from <<< in record_versions.sh
 	_, err = io.WriteString(hasher, hashMsg)
 	require.NoError(t, err, "failed to hash compiler artifact %s", apath)
 	recompileCommand := fmt.Sprintf("`%s && go generate`", compileCommand(t))
@@ -97,12 +90,6 @@ func compareCurrentCompilerAritfactAgainstRecordsAndSoliditySources(
 		"compiler artifact %s has changed; please rerun %s for the vrf package",
 		apath, recompileCommand)
 
-	var artifact struct {
-		Sources map[string]string `json:"sourceCodes"`
-	}
-	require.NoError(t, json.Unmarshal(compilerJSON, &artifact),
-		"could not read compiler artifact %s", apath)
-
 	if !isLINKCompilerOutput { // No need to check contract source for LINK token
 		// Check that each of the contract source codes hasn't changed
 		soliditySourceRoot := filepath.Dir(filepath.Dir(filepath.Dir(apath)))
@@ -0,0 +1,106 @@
+package vrf_test
+
+// This file complements TestCheckContractHashesFromLastGoGenerate: that test
+// only checks that a wrapper's compiler artifact hasn't drifted, it never
+// loads the generated Go bindings themselves, so a regression in wrapper
+// generation that still compiles (wrong method signature, mis-packed
+// arguments, ...) goes undetected until it's exercised in production. Here
+// we deploy each generated wrapper to a simulated backend and drive a
+// representative read and write method through it.
+//
+// As more VRF contracts grow generated wrappers (e.g. a VRFConsumer), give
+// each one an analogous TestXWrapperRoundTrips alongside these.
+
+import (
+	"math/big"
+	"testing"
+
+	"chainlink/core/internal/gethwrappers/generated/link_token_interface"
+	"chainlink/core/internal/gethwrappers/generated/solidity_vrf_coordinator_interface"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// simulatedBackendGasLimit is a generous per-block gas limit for the
+// simulated backend; VRF wrapper deployments run larger than a typical ERC20
+// deploy.
+const simulatedBackendGasLimit = uint64(8000000)
+
+// oneEther is 1e18 wei, i.e. one ether; GenesisAlloc balances are in wei.
+var oneEther = big.NewInt(1e18)
+
+// newSimulatedBackend spins up a SimulatedBackend with a single funded test
+// key, for exercising generated contract wrappers end-to-end.
+func newSimulatedBackend(t *testing.T) (*backends.SimulatedBackend, *bind.TransactOpts) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err, "failed to generate test key")
+	auth := bind.NewKeyedTransactor(key)
+	alloc := core.GenesisAlloc{auth.From: {Balance: oneEther}}
+	return backends.NewSimulatedBackend(alloc, simulatedBackendGasLimit), auth
+}
+
+// TestLinkTokenWrapperRoundTrips deploys the generated LinkToken wrapper to a
+// simulated backend and exercises a representative read (BalanceOf) and
+// write (Transfer) method, to make sure calldata encoding for the generated
+// bindings round-trips correctly.
+func TestLinkTokenWrapperRoundTrips(t *testing.T) {
+	backend, auth := newSimulatedBackend(t)
+
+	_, _, token, err := link_token_interface.DeployLinkToken(auth, backend)
+	require.NoError(t, err, "failed to deploy LinkToken")
+	backend.Commit()
+
+	deployerBalance, err := token.BalanceOf(nil, auth.From)
+	require.NoError(t, err)
+	require.True(t, deployerBalance.Sign() > 0, "deployer should hold the initial LINK supply")
+
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000001234")
+	amount := big.NewInt(1000)
+	_, err = token.Transfer(auth, recipient, amount)
+	require.NoError(t, err, "failed to submit Transfer")
+	backend.Commit()
+
+	recipientBalance, err := token.BalanceOf(nil, recipient)
+	require.NoError(t, err)
+	require.Equal(t, 0, recipientBalance.Cmp(amount), "recipient should have received the transferred LINK")
+}
+
+// TestVRFCoordinatorWrapperRoundTrips deploys the generated VRFCoordinator
+// wrapper to a simulated backend and exercises a representative write
+// (RegisterProvingKey) and read (ServiceAgreements), to catch a regression in
+// VRF wrapper generation -- the specific case this file exists to guard --
+// that TestCheckContractHashesFromLastGoGenerate's hash-only comparison would
+// miss.
+func TestVRFCoordinatorWrapperRoundTrips(t *testing.T) {
+	backend, auth := newSimulatedBackend(t)
+
+	linkAddress, _, _, err := link_token_interface.DeployLinkToken(auth, backend)
+	require.NoError(t, err, "failed to deploy LinkToken")
+	backend.Commit()
+
+	_, _, coordinator, err := solidity_vrf_coordinator_interface.DeployVRFCoordinator(auth, backend, linkAddress, common.Address{})
+	require.NoError(t, err, "failed to deploy VRFCoordinator")
+	backend.Commit()
+
+	fee := big.NewInt(1e18)
+	publicProvingKey := [2]*big.Int{big.NewInt(1), big.NewInt(2)}
+	var jobID [32]byte
+	copy(jobID[:], []byte("test-job-id"))
+
+	_, err = coordinator.RegisterProvingKey(auth, fee, auth.From, publicProvingKey, jobID)
+	require.NoError(t, err, "failed to submit RegisterProvingKey")
+	backend.Commit()
+
+	keyHash, err := coordinator.HashOfKey(nil, publicProvingKey)
+	require.NoError(t, err)
+
+	registeredFee, registeredOracle, err := coordinator.ServiceAgreements(nil, keyHash)
+	require.NoError(t, err)
+	require.Equal(t, 0, registeredFee.Cmp(fee), "registered fee should match what RegisterProvingKey submitted")
+	require.Equal(t, auth.From, registeredOracle, "registered oracle should match what RegisterProvingKey submitted")
+}